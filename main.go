@@ -1,21 +1,66 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"regexp"
 	"strings"
+	"time"
 
-	"github.com/PuerkitoBio/goquery"
 	"github.com/urfave/cli/v2"
+
+	"github.com/tr3ss/gohfinder/pkg/finder"
+	"github.com/tr3ss/gohfinder/pkg/httpx"
+	"github.com/tr3ss/gohfinder/pkg/output"
+	"github.com/tr3ss/gohfinder/pkg/render"
+	"github.com/tr3ss/gohfinder/pkg/sources/bgphe"
+	"github.com/tr3ss/gohfinder/pkg/sources/ct"
+	"github.com/tr3ss/gohfinder/pkg/sources/robtex"
+	"github.com/tr3ss/gohfinder/pkg/verify"
 )
 
+// sourceRegistry maps a --sources name to its constructor. browser is
+// nil unless --render was passed; sources with no render fallback (like
+// ct, which talks to a JSON API) simply ignore it.
+var sourceRegistry = map[string]func(client *http.Client, browser *render.Browser) finder.Source{
+	"bgp": func(client *http.Client, browser *render.Browser) finder.Source {
+		s := bgphe.New(client)
+		s.Render = browser
+		return s
+	},
+	"robtex": func(client *http.Client, browser *render.Browser) finder.Source {
+		s := robtex.New(client)
+		s.Render = browser
+		return s
+	},
+	"ct": func(client *http.Client, browser *render.Browser) finder.Source {
+		return ct.New(client)
+	},
+}
+
+const defaultSources = "ct,robtex,bgp"
+
+func buildSources(names string, client *http.Client, browser *render.Browser) ([]finder.Source, error) {
+	var sources []finder.Source
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		ctor, ok := sourceRegistry[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown source %q", name)
+		}
+		sources = append(sources, ctor(client, browser))
+	}
+	return sources, nil
+}
+
 func main() {
 	app := &cli.App{
 		Name:  "gohfinder",
-		Usage: "Find hostnames from ASN or CIDR - Robtex x BGP.HE",
+		Usage: "Find hostnames from ASN or CIDR - Robtex x BGP.HE x crt.sh",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "cidr",
@@ -39,211 +84,111 @@ func main() {
 				Name:  "filter",
 				Usage: "Filter FQDN against regex",
 			},
+			&cli.StringFlag{
+				Name:  "sources",
+				Usage: "Comma-separated data sources to query (ct, robtex, bgp)",
+				Value: defaultSources,
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of CIDRs/ASNs to scan in parallel",
+				Value: 10,
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "Per-request HTTP timeout",
+				Value: 30 * time.Second,
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output format: text, json, ndjson, csv",
+				Value: "text",
+			},
+			&cli.BoolFlag{
+				Name:  "render",
+				Usage: "Fall back to a headless Chromium session when HTTP scraping finds zero rows",
+			},
+			&cli.BoolFlag{
+				Name:  "verify",
+				Usage: "Actively re-resolve discovered hostnames and drop ones that no longer point into the queried range",
+			},
+			&cli.StringFlag{
+				Name:  "resolver",
+				Usage: "Nameserver used by --verify: host:port for plain DNS, tls://host:port for DoT, https://host/path for DoH",
+				Value: "1.1.1.1:53",
+			},
+			&cli.BoolFlag{
+				Name:  "include-stale",
+				Usage: "With --verify, keep hostnames that failed to verify, tagged as stale, instead of dropping them",
+			},
 		},
 		Action: func(c *cli.Context) error {
-			if c.String("cidr") != "" {
-				cidrs := strings.Split(c.String("cidr"), ",")
-				results := make(map[string]map[string]struct{})
-				for _, cidr := range cidrs {
-					res := searchCIDR(cidr)
-					for k, v := range res {
-						if _, exists := results[k]; !exists {
-							results[k] = make(map[string]struct{})
-						}
-						for ip := range v {
-							results[k][ip] = struct{}{}
-						}
-					}
-				}
-				printResults(results, c.Bool("hosts"), c.Bool("fqdn"), c.String("filter"))
-			} else if c.String("asn") != "" {
-				asns := strings.Split(c.String("asn"), ",")
-				results := make(map[string]map[string]struct{})
-				for _, asn := range asns {
-					ranges := searchASN(asn)
-					for _, rangeCIDR := range ranges {
-						res := searchCIDR(rangeCIDR)
-						for k, v := range res {
-							if _, exists := results[k]; !exists {
-								results[k] = make(map[string]struct{})
-							}
-							for ip := range v {
-								results[k][ip] = struct{}{}
-							}
-						}
-					}
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt)
+			defer stop()
+
+			var filter output.FilterFunc
+			if pattern := c.String("filter"); pattern != "" {
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					return fmt.Errorf("invalid regex pattern: %w", err)
 				}
-				printResults(results, c.Bool("hosts"), c.Bool("fqdn"), c.String("filter"))
-			} else {
-				return fmt.Errorf("Invalid parameters. Please provide either -c or -a")
+				filter = re.MatchString
 			}
-			return nil
-		},
-	}
-
-	err := app.Run(os.Args)
-	if err != nil {
-		log.Fatal(err)
-	}
-}
-
-// Set a custom User-Agent to avoid being blocked by websites.
-const userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36"
-
-func searchASN(asns string) []string {
-	bgpURL := "https://bgp.he.net/"
-	asnList := strings.Split(asns, ",")
-	var ranges []string
-
-	for _, asn := range asnList {
-		req, err := http.NewRequest("GET", bgpURL+asn, nil)
-		if err != nil {
-			log.Fatalf("Failed to create HTTP request: %v", err)
-			return nil
-		}
-
-		// Set a custom User-Agent
-		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36")
-
-		client := &http.Client{}
-		resp, err := client.Do(req)
-		if err != nil {
-			//			log.Printf("Failed to fetch BGP.HE ASN page: %v", err)
-			continue
-		}
-		defer resp.Body.Close()
-
-		// Check if the request was successful
-		if resp.StatusCode != http.StatusOK {
-			//			log.Printf("Failed to fetch BGP.HE ASN page: status code %d", resp.StatusCode)
-			continue
-		}
 
-		// Parse the HTML
-		doc, err := goquery.NewDocumentFromReader(resp.Body)
-		if err != nil {
-			//			log.Printf("Error parsing ASN HTML: %v", err)
-			continue
-		}
-
-		// Extract ASN ranges from the specified table
-		doc.Find("#table_prefixes4 tbody tr").Each(func(i int, s *goquery.Selection) {
-			// Extract prefix (the first <td> which contains the <a> tag)
-			prefix := s.Find("td").First().Find("a").Text()
-
-			// Clean up the string
-			prefix = strings.TrimSpace(prefix)
-
-			// Add the prefix to the ranges if it's not empty
-			if prefix != "" {
-				ranges = append(ranges, prefix)
+			out, err := output.New(c.String("output"), os.Stdout, output.Options{
+				Hosts:  c.Bool("hosts"),
+				FQDN:   c.Bool("fqdn"),
+				Filter: filter,
+			})
+			if err != nil {
+				return err
 			}
-		})
 
-		for _, rangeEntry := range ranges {
-			fmt.Println(rangeEntry) // Print each range on a new line
-		}
-	}
-
-	return ranges
-}
-
-func searchCIDR(cidr string) map[string]map[string]struct{} {
-	robtexURL := "https://www.robtex.com/cidr/"
-	uri := strings.Replace(cidr, "/", "-", -1)
-
-	// Create a new HTTP request
-	req, err := http.NewRequest("GET", robtexURL+uri, nil)
-	if err != nil {
-		log.Fatalf("Failed to create HTTP request: %v", err)
-		return nil
-	}
-
-	// Set the User-Agent header to avoid blocking
-	req.Header.Set("User-Agent", userAgent)
-
-	// Send the HTTP request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		//		log.Printf("Failed to fetch Robtex CIDR page: %v", err)
-		return nil
-	}
-	defer resp.Body.Close()
-
-	// Check if the request was successful
-	if resp.StatusCode != http.StatusOK {
-		//		log.Printf("Failed to fetch Robtex CIDR page: status code %d", resp.StatusCode)
-		return nil
-	}
-
-	// Parse the HTML using goquery
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		//		log.Fatalf("Error parsing CIDR HTML: %v", err)
-		return nil
-	}
-
-	// Collect hostnames and IPs
-	hostnames := make(map[string]map[string]struct{})
-	doc.Find("a[href^='https://www.robtex.com/dns-lookup/']").Each(func(i int, s *goquery.Selection) {
-		h := strings.Replace(s.AttrOr("href", ""), "https://www.robtex.com/dns-lookup/", "", 1)
-		ip := s.Parent().Parent().Find("a[href^='https://www.robtex.com/ip-lookup/']").AttrOr("href", "")
-		ip = strings.Replace(ip, "https://www.robtex.com/ip-lookup/", "", 1)
-
-		if _, exists := hostnames[h]; !exists {
-			hostnames[h] = make(map[string]struct{})
-		}
-		hostnames[h][ip] = struct{}{}
-	})
+			var browser *render.Browser
+			if c.Bool("render") {
+				browser = render.NewBrowser(ctx)
+				defer browser.Close()
+			}
 
-	return hostnames
-}
+			client := httpx.NewClient(httpx.Config{Timeout: c.Duration("timeout")})
+			sources, err := buildSources(c.String("sources"), client, browser)
+			if err != nil {
+				return err
+			}
+			scanner := finder.NewScanner(finder.New(sources...), c.Int("concurrency"))
 
-func printResults(results map[string]map[string]struct{}, hosts bool, fqdn bool, filter string) {
-	var re *regexp.Regexp
-	var err error
-	if filter != "" {
-		// Compile the regex pattern
-		re, err = regexp.Compile(filter)
-		if err != nil {
-			log.Fatalf("Invalid regex pattern: %v", err)
-		}
-	}
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
 
-	if hosts {
-		hostsResult := make(map[string]map[string]struct{})
-		for hostname, ips := range results {
-			if filter == "" || re.MatchString(hostname) {
-				for ip := range ips {
-					if _, exists := hostsResult[ip]; !exists {
-						hostsResult[ip] = make(map[string]struct{})
-					}
-					hostsResult[ip][hostname] = struct{}{}
+			emit := func(o finder.Observation) {
+				if err := out.Write(o); err != nil {
+					log.Printf("write observation: %v", err)
+					cancel()
 				}
 			}
-		}
+			wait := func() {}
+			if c.Bool("verify") {
+				resolver := verify.NewResolver(c.String("resolver"))
+				emit, wait = verify.Pipeline(ctx, resolver, c.Int("concurrency"), c.Bool("include-stale"), emit)
+			}
 
-		for ip, hostnames := range hostsResult {
-			var hostnameList []string
-			for h := range hostnames {
-				hostnameList = append(hostnameList, h)
+			if c.String("cidr") != "" {
+				err = scanner.StreamCIDRs(ctx, strings.Split(c.String("cidr"), ","), emit)
+			} else if c.String("asn") != "" {
+				err = scanner.StreamASNs(ctx, strings.Split(c.String("asn"), ","), emit)
+			} else {
+				return fmt.Errorf("Invalid parameters. Please provide either -c or -a")
 			}
-			fmt.Printf("%s %s\n", ip, strings.Join(hostnameList, " "))
-		}
-	} else {
-		for hostname, ips := range results {
-			if filter == "" || re.MatchString(hostname) {
-				var ipList []string
-				for ip := range ips {
-					ipList = append(ipList, ip)
-				}
-				if fqdn {
-					fmt.Println(hostname)
-				} else {
-					fmt.Printf("%s: %s\n", hostname, strings.Join(ipList, " "))
-				}
+			wait()
+			if err != nil && err != context.Canceled {
+				return err
 			}
-		}
+
+			return out.Close()
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
 	}
 }
@@ -0,0 +1,114 @@
+// Package finder orchestrates lookups across one or more data-source
+// providers and merges their results into a single hostname/IP map.
+package finder
+
+import "context"
+
+// Prefix is an announced CIDR block discovered for an ASN.
+type Prefix struct {
+	CIDR   string
+	Family string // "ipv4" or "ipv6"
+}
+
+// Host is a hostname/IP pair discovered within a CIDR. IP may be empty
+// for sources (like Certificate Transparency logs) that discover
+// hostnames without an associated address.
+type Host struct {
+	Hostname string
+	IP       string
+	Family   string // "ipv4" or "ipv6"
+}
+
+// Source is implemented by data providers that can resolve the prefixes
+// announced by an ASN and/or enumerate hosts within a CIDR. A provider
+// that doesn't support one of the two lookups should return a nil slice
+// and a nil error rather than an error.
+type Source interface {
+	// Name identifies the source, e.g. for CLI selection and output tagging.
+	Name() string
+	LookupASN(ctx context.Context, asn string) ([]Prefix, error)
+	LookupCIDR(ctx context.Context, cidr string) ([]Host, error)
+}
+
+// OrgLookupSource is an optional extension of Source for providers (such
+// as Certificate Transparency logs) that can resolve hosts directly from
+// an ASN's registered organization name, bypassing CIDR prefixes
+// entirely. Finder.StreamASN (and so FindASN) type-asserts for it on each
+// registered Source.
+type OrgLookupSource interface {
+	LookupOrg(ctx context.Context, asn string) ([]Host, error)
+}
+
+// Record is the set of IPs and contributing sources discovered for a
+// single hostname.
+type Record struct {
+	IPs     map[string]struct{}
+	Sources map[string]struct{}
+}
+
+func newRecord() *Record {
+	return &Record{IPs: make(map[string]struct{}), Sources: make(map[string]struct{})}
+}
+
+// Results maps hostname -> the record discovered for it.
+type Results map[string]*Record
+
+// add folds a single (hostname, ip, source) observation into r.
+func (r Results) add(hostname, ip, source string) {
+	rec, exists := r[hostname]
+	if !exists {
+		rec = newRecord()
+		r[hostname] = rec
+	}
+	if ip != "" {
+		rec.IPs[ip] = struct{}{}
+	}
+	if source != "" {
+		rec.Sources[source] = struct{}{}
+	}
+}
+
+// Merge folds src into r, taking the union of IPs and sources per hostname.
+func (r Results) Merge(src Results) {
+	for hostname, rec := range src {
+		for ip := range rec.IPs {
+			r.add(hostname, ip, "")
+		}
+		for source := range rec.Sources {
+			r.add(hostname, "", source)
+		}
+	}
+}
+
+// Finder fans out lookups across its registered sources and merges
+// their results.
+type Finder struct {
+	Sources []Source
+}
+
+// New builds a Finder backed by the given sources.
+func New(sources ...Source) *Finder {
+	return &Finder{Sources: sources}
+}
+
+// FindCIDR asks every registered source for the hosts it knows about
+// within cidr and merges them into a single Results map.
+func (f *Finder) FindCIDR(ctx context.Context, cidr string) Results {
+	results := make(Results)
+	f.StreamCIDR(ctx, cidr, "", func(o Observation) {
+		results.add(o.Hostname, o.IP, o.Source)
+	})
+	return results
+}
+
+// FindASN resolves the prefixes announced by asn via every registered
+// source, then merges the FindCIDR results for each discovered prefix.
+// Sources that also implement OrgLookupSource additionally contribute
+// hosts resolved directly from the ASN's organization name.
+func (f *Finder) FindASN(ctx context.Context, asn string) Results {
+	results := make(Results)
+	f.StreamASN(ctx, asn, func(o Observation) {
+		results.add(o.Hostname, o.IP, o.Source)
+	})
+	return results
+}
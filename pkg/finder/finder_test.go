@@ -0,0 +1,91 @@
+package finder
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// stubSource returns a fixed set of hosts for any CIDR and no ASN prefixes,
+// or the configured err instead when it's non-nil.
+type stubSource struct {
+	name  string
+	hosts []Host
+	err   error
+}
+
+func (s *stubSource) Name() string { return s.name }
+
+func (s *stubSource) LookupASN(ctx context.Context, asn string) ([]Prefix, error) {
+	return nil, nil
+}
+
+func (s *stubSource) LookupCIDR(ctx context.Context, cidr string) ([]Host, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.hosts, nil
+}
+
+func TestScanCIDRsSourcesContainOnlyRealNames(t *testing.T) {
+	f := New(
+		&stubSource{name: "a", hosts: []Host{{Hostname: "x.example.com", IP: "203.0.113.1"}}},
+		&stubSource{name: "b", hosts: []Host{{Hostname: "x.example.com", IP: "203.0.113.2"}}},
+	)
+	scanner := NewScanner(f, 2)
+
+	results, err := scanner.ScanCIDRs(context.Background(), []string{"203.0.113.0/24"})
+	if err != nil {
+		t.Fatalf("ScanCIDRs returned error: %v", err)
+	}
+
+	rec, ok := results["x.example.com"]
+	if !ok {
+		t.Fatalf("expected a record for x.example.com, got %v", results)
+	}
+	if _, bogus := rec.Sources[""]; bogus {
+		t.Fatalf("Sources contains a bogus empty-string entry: %v", rec.Sources)
+	}
+	want := map[string]struct{}{"a": {}, "b": {}}
+	if len(rec.Sources) != len(want) {
+		t.Fatalf("got Sources=%v, want %v", rec.Sources, want)
+	}
+	for name := range want {
+		if _, ok := rec.Sources[name]; !ok {
+			t.Fatalf("missing source %q in %v", name, rec.Sources)
+		}
+	}
+}
+
+func TestMergeDropsEmptySourceNames(t *testing.T) {
+	r := make(Results)
+	r.add("host", "203.0.113.1", "real-source")
+
+	other := make(Results)
+	other.add("host", "203.0.113.2", "")
+
+	r.Merge(other)
+
+	if _, bogus := r["host"].Sources[""]; bogus {
+		t.Fatalf("Merge injected a bogus empty-string source: %v", r["host"].Sources)
+	}
+	if len(r["host"].IPs) != 2 {
+		t.Fatalf("got %d IPs, want 2", len(r["host"].IPs))
+	}
+}
+
+func TestStreamCIDRContinuesPastAFailingSource(t *testing.T) {
+	f := New(
+		&stubSource{name: "broken", err: errors.New("boom")},
+		&stubSource{name: "ok", hosts: []Host{{Hostname: "x.example.com", IP: "203.0.113.1"}}},
+	)
+
+	var got []Observation
+	f.StreamCIDR(context.Background(), "203.0.113.0/24", "", func(o Observation) {
+		got = append(got, o)
+	})
+
+	if len(got) != 1 || got[0].Hostname != "x.example.com" {
+		t.Fatalf("got %v, want a single observation from the working source", got)
+	}
+}
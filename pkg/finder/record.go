@@ -0,0 +1,93 @@
+package finder
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Observation is a single hostname/IP record tagged with its
+// provenance. It is the stable schema consumed by structured output
+// formats (JSON, NDJSON, CSV) as well as the plain-text formatters, so
+// every format renders the same underlying data.
+type Observation struct {
+	Hostname  string    `json:"hostname"`
+	IP        string    `json:"ip,omitempty"`
+	Family    string    `json:"family,omitempty"` // "ipv4" or "ipv6", when known
+	CIDR      string    `json:"cidr,omitempty"`
+	ASN       string    `json:"asn,omitempty"`
+	Source    string    `json:"source"`
+	FirstSeen time.Time `json:"first_seen"`
+
+	// The following are populated only in --verify mode, by actively
+	// re-resolving Hostname against a configured nameserver.
+	Verified bool     `json:"verified,omitempty"`
+	Stale    bool     `json:"stale,omitempty"`
+	LiveA    []string `json:"live_a,omitempty"`
+	LiveAAAA []string `json:"live_aaaa,omitempty"`
+	CNAME    []string `json:"cname,omitempty"`
+}
+
+// StreamCIDR calls emit for every host discovered within cidr, as soon
+// as each source returns its results, rather than buffering them into a
+// Results map first. asn is recorded on each Observation when the CIDR
+// lookup was reached while resolving an ASN's prefixes; pass "" for a
+// standalone CIDR lookup.
+func (f *Finder) StreamCIDR(ctx context.Context, cidr, asn string, emit func(Observation)) {
+	for _, src := range f.Sources {
+		hosts, err := src.LookupCIDR(ctx, cidr)
+		if err != nil {
+			log.Printf("finder: %s: lookup CIDR %s: %v", src.Name(), cidr, err)
+			continue
+		}
+		for _, h := range hosts {
+			emit(Observation{
+				Hostname:  h.Hostname,
+				IP:        h.IP,
+				Family:    h.Family,
+				CIDR:      cidr,
+				ASN:       asn,
+				Source:    src.Name(),
+				FirstSeen: time.Now(),
+			})
+		}
+	}
+}
+
+// StreamASN resolves the prefixes announced by asn via every registered
+// source and streams an Observation for every host discovered within
+// them, as soon as each is found. Sources that also implement
+// OrgLookupSource additionally stream hosts resolved directly from the
+// ASN's organization name.
+func (f *Finder) StreamASN(ctx context.Context, asn string, emit func(Observation)) {
+	for _, src := range f.Sources {
+		prefixes, err := src.LookupASN(ctx, asn)
+		if err != nil {
+			log.Printf("finder: %s: lookup ASN %s: %v", src.Name(), asn, err)
+		} else {
+			for _, p := range prefixes {
+				f.StreamCIDR(ctx, p.CIDR, asn, emit)
+			}
+		}
+
+		orgSrc, ok := src.(OrgLookupSource)
+		if !ok {
+			continue
+		}
+		hosts, err := orgSrc.LookupOrg(ctx, asn)
+		if err != nil {
+			log.Printf("finder: %s: lookup org for %s: %v", src.Name(), asn, err)
+			continue
+		}
+		for _, h := range hosts {
+			emit(Observation{
+				Hostname:  h.Hostname,
+				IP:        h.IP,
+				Family:    h.Family,
+				ASN:       asn,
+				Source:    src.Name(),
+				FirstSeen: time.Now(),
+			})
+		}
+	}
+}
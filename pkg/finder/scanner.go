@@ -0,0 +1,117 @@
+package finder
+
+import (
+	"context"
+	"sync"
+)
+
+// Scanner drives CIDR/ASN lookups across a bounded worker pool, so a
+// large batch of targets doesn't run strictly sequentially.
+type Scanner struct {
+	Finder      *Finder
+	Concurrency int
+}
+
+// NewScanner returns a Scanner backed by f with the given worker count.
+// A non-positive concurrency is treated as 1.
+func NewScanner(f *Finder, concurrency int) *Scanner {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &Scanner{Finder: f, Concurrency: concurrency}
+}
+
+// ScanCIDRs runs FindCIDR over cidrs across the worker pool and merges
+// the results. If ctx is canceled before every worker completes, it
+// returns the results merged so far along with ctx.Err().
+func (s *Scanner) ScanCIDRs(ctx context.Context, cidrs []string) (Results, error) {
+	return s.scan(ctx, cidrs, s.Finder.FindCIDR)
+}
+
+// ScanASNs runs FindASN over asns across the worker pool and merges the
+// results. If ctx is canceled before every worker completes, it returns
+// the results merged so far along with ctx.Err().
+func (s *Scanner) ScanASNs(ctx context.Context, asns []string) (Results, error) {
+	return s.scan(ctx, asns, s.Finder.FindASN)
+}
+
+func (s *Scanner) scan(ctx context.Context, items []string, lookup func(context.Context, string) Results) (Results, error) {
+	results := make(Results)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, s.Concurrency)
+
+items:
+	for _, item := range items {
+		select {
+		case <-ctx.Done():
+			break items
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(item string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res := lookup(ctx, item)
+
+			mu.Lock()
+			results.Merge(res)
+			mu.Unlock()
+		}(item)
+	}
+
+	wg.Wait()
+	return results, ctx.Err()
+}
+
+// StreamCIDRs runs StreamCIDR over cidrs across the worker pool, calling
+// emit for every Observation as soon as it's discovered. emit is called
+// from multiple goroutines but never concurrently with itself.
+func (s *Scanner) StreamCIDRs(ctx context.Context, cidrs []string, emit func(Observation)) error {
+	return s.stream(ctx, cidrs, func(ctx context.Context, item string, emit func(Observation)) {
+		s.Finder.StreamCIDR(ctx, item, "", emit)
+	}, emit)
+}
+
+// StreamASNs runs StreamASN over asns across the worker pool, calling
+// emit for every Observation as soon as it's discovered. emit is called
+// from multiple goroutines but never concurrently with itself.
+func (s *Scanner) StreamASNs(ctx context.Context, asns []string, emit func(Observation)) error {
+	return s.stream(ctx, asns, func(ctx context.Context, item string, emit func(Observation)) {
+		s.Finder.StreamASN(ctx, item, emit)
+	}, emit)
+}
+
+func (s *Scanner) stream(ctx context.Context, items []string, lookup func(context.Context, string, func(Observation)), emit func(Observation)) error {
+	var mu sync.Mutex
+	safeEmit := func(o Observation) {
+		mu.Lock()
+		defer mu.Unlock()
+		emit(o)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, s.Concurrency)
+
+items:
+	for _, item := range items {
+		select {
+		case <-ctx.Done():
+			break items
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(item string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			lookup(ctx, item, safeEmit)
+		}(item)
+	}
+
+	wg.Wait()
+	return ctx.Err()
+}
@@ -0,0 +1,118 @@
+// Package httpx builds HTTP clients shared by the source providers,
+// applying per-host rate limiting and exponential backoff with jitter on
+// 429/5xx responses so a large scan doesn't get throttled or banned.
+package httpx
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Config controls the rate limiting and retry behavior applied to every
+// request made through a Client built by NewClient.
+type Config struct {
+	// RequestsPerSecond caps outbound requests per second, per host.
+	// Defaults to 2 if zero.
+	RequestsPerSecond float64
+	// MaxRetries bounds the number of retries on 429/5xx responses.
+	// Defaults to 3 if zero.
+	MaxRetries int
+	// Timeout bounds the total duration of a request, including every
+	// retry and backoff wait. Zero means no timeout is applied by the
+	// client itself.
+	Timeout time.Duration
+}
+
+// NewClient returns an *http.Client whose transport rate limits requests
+// per host and retries 429/5xx responses with exponential backoff and
+// jitter.
+func NewClient(cfg Config) *http.Client {
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &limitedTransport{
+			base:     http.DefaultTransport,
+			cfg:      cfg,
+			limiters: make(map[string]*rate.Limiter),
+		},
+	}
+}
+
+type limitedTransport struct {
+	base     http.RoundTripper
+	cfg      Config
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func (t *limitedTransport) limiterFor(host string) *rate.Limiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.limiters[host]
+	if !ok {
+		rps := t.cfg.RequestsPerSecond
+		if rps <= 0 {
+			rps = 2
+		}
+		l = rate.NewLimiter(rate.Limit(rps), 1)
+		t.limiters[host] = l
+	}
+	return l
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxRetries := t.cfg.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+	limiter := t.limiterFor(req.URL.Host)
+
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		if err := limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		var err error
+		resp, err = t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !shouldRetry(resp.StatusCode) || attempt == maxRetries {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		if err := sleepBackoff(req, attempt); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func shouldRetry(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// sleepBackoff waits an exponentially increasing, jittered delay before
+// the next retry attempt, or returns early if the request's context is
+// canceled first.
+func sleepBackoff(req *http.Request, attempt int) error {
+	base := time.Duration(math.Pow(2, float64(attempt))) * 250 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base)/2 + 1))
+
+	timer := time.NewTimer(base + jitter)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
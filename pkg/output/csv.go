@@ -0,0 +1,43 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	"github.com/tr3ss/gohfinder/pkg/finder"
+)
+
+var csvHeader = []string{"hostname", "ip", "family", "cidr", "asn", "source", "first_seen"}
+
+// csvWriter streams one row per Observation as it's received.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	cw := csv.NewWriter(w)
+	cw.Write(csvHeader)
+	return &csvWriter{w: cw}
+}
+
+func (c *csvWriter) Write(o finder.Observation) error {
+	if err := c.w.Write([]string{
+		o.Hostname,
+		o.IP,
+		o.Family,
+		o.CIDR,
+		o.ASN,
+		o.Source,
+		o.FirstSeen.Format(time.RFC3339),
+	}); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/tr3ss/gohfinder/pkg/finder"
+)
+
+func TestCSVWriterWritesHeaderAndRows(t *testing.T) {
+	var buf bytes.Buffer
+	w := newCSVWriter(&buf)
+
+	o := finder.Observation{
+		Hostname:  "a.example.com",
+		IP:        "203.0.113.1",
+		Family:    "ipv4",
+		CIDR:      "203.0.113.0/24",
+		ASN:       "AS64500",
+		Source:    "robtex",
+		FirstSeen: time.Unix(0, 0).UTC(),
+	}
+	if err := w.Write(o); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2 (header + record)", len(rows))
+	}
+	if rows[0][0] != "hostname" || rows[0][2] != "family" {
+		t.Fatalf("unexpected header: %v", rows[0])
+	}
+	if rows[1][0] != o.Hostname || rows[1][2] != o.Family {
+		t.Fatalf("unexpected row: %v", rows[1])
+	}
+}
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/tr3ss/gohfinder/pkg/finder"
+)
+
+// jsonWriter buffers every Observation and writes a single JSON array on
+// Close, since a JSON array can't be closed until the last record is known.
+type jsonWriter struct {
+	w    io.Writer
+	recs []finder.Observation
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: w}
+}
+
+func (j *jsonWriter) Write(o finder.Observation) error {
+	j.recs = append(j.recs, o)
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	enc := json.NewEncoder(j.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(j.recs)
+}
+
+// ndjsonWriter writes each Observation as its own JSON line the moment
+// it's received, so downstream tools like jq can consume records as a
+// scan progresses instead of waiting for it to finish.
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	return &ndjsonWriter{enc: json.NewEncoder(w)}
+}
+
+func (n *ndjsonWriter) Write(o finder.Observation) error {
+	return n.enc.Encode(o)
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tr3ss/gohfinder/pkg/finder"
+)
+
+func TestJSONWriterEncodesAllRecordsOnClose(t *testing.T) {
+	var buf bytes.Buffer
+	w := newJSONWriter(&buf)
+
+	recs := []finder.Observation{
+		{Hostname: "a.example.com", IP: "203.0.113.1", Source: "robtex", FirstSeen: time.Unix(0, 0).UTC()},
+		{Hostname: "b.example.com", IP: "203.0.113.2", Source: "bgp", FirstSeen: time.Unix(0, 0).UTC()},
+	}
+	for _, r := range recs {
+		if err := w.Write(r); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	var got []finder.Observation
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a valid JSON array: %v", err)
+	}
+	if len(got) != len(recs) {
+		t.Fatalf("got %d records, want %d", len(got), len(recs))
+	}
+}
+
+func TestNDJSONWriterEmitsOneLinePerWrite(t *testing.T) {
+	var buf bytes.Buffer
+	w := newNDJSONWriter(&buf)
+
+	if err := w.Write(finder.Observation{Hostname: "a.example.com"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Write(finder.Observation{Hostname: "b.example.com"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		var o finder.Observation
+		if err := json.Unmarshal([]byte(line), &o); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", line, err)
+		}
+	}
+}
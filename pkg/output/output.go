@@ -0,0 +1,53 @@
+// Package output formats finder.Observation records for the CLI.
+// Every supported --output value is a Writer over the same underlying
+// record stream, so text, /etc/hosts, JSON, NDJSON and CSV are all
+// formatters rather than special-cased code paths.
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tr3ss/gohfinder/pkg/finder"
+)
+
+// Writer consumes a stream of Observations and renders them in a
+// particular format. Write may be called many times; Close flushes any
+// buffered output and must be called exactly once, after the last Write.
+type Writer interface {
+	Write(o finder.Observation) error
+	Close() error
+}
+
+// Options configures the text formatter. It's ignored by the structured
+// formats (JSON, NDJSON, CSV). JSON and NDJSON emit every Observation
+// field; CSV emits only the columns in csvHeader, so it carries no
+// --verify fields (verified, stale, live_a, live_aaaa, cname).
+type Options struct {
+	// Hosts renders "<ip> <hostname...>" lines instead of "<hostname>: <ip...>".
+	Hosts bool
+	// FQDN renders bare hostnames with no IPs or sources.
+	FQDN bool
+	// Filter, if non-nil, drops hostnames that don't match it.
+	Filter FilterFunc
+}
+
+// FilterFunc reports whether a hostname should be included in the output.
+type FilterFunc func(hostname string) bool
+
+// New returns the Writer for the given --output format ("text", "json",
+// "ndjson" or "csv").
+func New(format string, w io.Writer, opts Options) (Writer, error) {
+	switch format {
+	case "", "text":
+		return newTextWriter(w, opts), nil
+	case "json":
+		return newJSONWriter(w), nil
+	case "ndjson":
+		return newNDJSONWriter(w), nil
+	case "csv":
+		return newCSVWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
@@ -0,0 +1,150 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/tr3ss/gohfinder/pkg/finder"
+)
+
+// textWriter reproduces gohfinder's original human-readable output: a
+// per-hostname summary, an /etc/hosts-style dump, or a bare FQDN list.
+// All three need every record for a hostname grouped together, so
+// textWriter buffers and renders the grouping on Close.
+type textWriter struct {
+	w    io.Writer
+	opts Options
+
+	order []string
+	hosts map[string]*hostSummary
+}
+
+type hostSummary struct {
+	ips      map[string]struct{}
+	families map[string]string // ip -> "ipv4"/"ipv6", when known
+	sources  map[string]struct{}
+	stale    bool
+}
+
+func newTextWriter(w io.Writer, opts Options) *textWriter {
+	return &textWriter{w: w, opts: opts, hosts: make(map[string]*hostSummary)}
+}
+
+func (t *textWriter) Write(o finder.Observation) error {
+	if t.opts.Filter != nil && !t.opts.Filter(o.Hostname) {
+		return nil
+	}
+
+	sum, exists := t.hosts[o.Hostname]
+	if !exists {
+		sum = &hostSummary{ips: make(map[string]struct{}), sources: make(map[string]struct{}), families: make(map[string]string)}
+		t.hosts[o.Hostname] = sum
+		t.order = append(t.order, o.Hostname)
+	}
+	if o.IP != "" {
+		sum.ips[o.IP] = struct{}{}
+		if o.Family != "" {
+			sum.families[o.IP] = o.Family
+		}
+	}
+	sum.sources[o.Source] = struct{}{}
+	if o.Stale {
+		sum.stale = true
+	}
+	return nil
+}
+
+func (t *textWriter) Close() error {
+	if t.opts.Hosts {
+		return t.writeHosts()
+	}
+	return t.writeSummary()
+}
+
+func (t *textWriter) writeHosts() error {
+	hostnamesByIP := make(map[string]map[string]struct{})
+	ipFamily := make(map[string]string)
+	var v4IPs, v6IPs []string
+	for _, hostname := range t.order {
+		sum := t.hosts[hostname]
+		for ip := range sum.ips {
+			if _, exists := hostnamesByIP[ip]; !exists {
+				hostnamesByIP[ip] = make(map[string]struct{})
+				family := sum.families[ip]
+				if family == "" {
+					family = guessFamily(ip)
+				}
+				ipFamily[ip] = family
+				if family == "ipv6" {
+					v6IPs = append(v6IPs, ip)
+				} else {
+					v4IPs = append(v4IPs, ip)
+				}
+			}
+			hostnamesByIP[ip][hostname] = struct{}{}
+		}
+	}
+	sort.Strings(v4IPs)
+	sort.Strings(v6IPs)
+
+	// /etc/hosts conventionally lists IPv4 entries before IPv6 ones.
+	for _, ip := range append(v4IPs, v6IPs...) {
+		var hostnameList []string
+		for h := range hostnamesByIP[ip] {
+			hostnameList = append(hostnameList, h)
+		}
+		sort.Strings(hostnameList)
+		if _, err := fmt.Fprintf(t.w, "%s %s\n", ip, strings.Join(hostnameList, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// guessFamily is a fallback for observations a Source didn't tag with a
+// Family (e.g. sources that only ever see IPv4), so --hosts grouping
+// stays correct even when Family is empty.
+func guessFamily(ip string) string {
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+		return "ipv6"
+	}
+	return "ipv4"
+}
+
+func (t *textWriter) writeSummary() error {
+	for _, hostname := range t.order {
+		if t.opts.FQDN {
+			if _, err := fmt.Fprintln(t.w, hostname); err != nil {
+				return err
+			}
+			continue
+		}
+
+		sum := t.hosts[hostname]
+
+		var ipList []string
+		for ip := range sum.ips {
+			ipList = append(ipList, ip)
+		}
+		sort.Strings(ipList)
+
+		var sourceList []string
+		for source := range sum.sources {
+			sourceList = append(sourceList, source)
+		}
+		sort.Strings(sourceList)
+
+		staleTag := ""
+		if sum.stale {
+			staleTag = " (stale)"
+		}
+
+		if _, err := fmt.Fprintf(t.w, "%s: %s [%s]%s\n", hostname, strings.Join(ipList, " "), strings.Join(sourceList, ","), staleTag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
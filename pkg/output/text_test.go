@@ -0,0 +1,32 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tr3ss/gohfinder/pkg/finder"
+)
+
+func TestTextWriterHostsGroupsByFamily(t *testing.T) {
+	var buf bytes.Buffer
+	w := newTextWriter(&buf, Options{Hosts: true})
+
+	recs := []finder.Observation{
+		{Hostname: "v6.example.com", IP: "2001:db8::1", Family: "ipv6"},
+		{Hostname: "b.example.com", IP: "203.0.113.2", Family: "ipv4"},
+		{Hostname: "a.example.com", IP: "203.0.113.1", Family: "ipv4"},
+	}
+	for _, r := range recs {
+		if err := w.Write(r); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	want := "203.0.113.1 a.example.com\n203.0.113.2 b.example.com\n2001:db8::1 v6.example.com\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,67 @@
+// Package render provides a shared headless-Chromium fallback for
+// sources whose HTTP scraping breaks against anti-bot interstitials or
+// lazy-loaded tables that never appear in the initial response body.
+package render
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Browser wraps a single shared Chromium allocator/context so its
+// startup cost is paid once and amortized across every CIDR/ASN a
+// --render-enabled source falls back to, instead of per lookup.
+type Browser struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewBrowser launches a shared headless Chromium instance bound to ctx;
+// it exits once ctx is canceled or Close is called.
+func NewBrowser(ctx context.Context) *Browser {
+	allocCtx, allocCancel := chromedp.NewExecAllocator(ctx, chromedp.DefaultExecAllocatorOptions[:]...)
+	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
+	// Run with no actions starts the browser on browserCtx itself, so
+	// every Fetch tab attaches to it; otherwise the first tab would
+	// allocate, and on close tear down, a browser of its own. If the
+	// launch fails here, Fetch returns the error from its own attempt.
+	_ = chromedp.Run(browserCtx)
+	return &Browser{
+		ctx: browserCtx,
+		cancel: func() {
+			browserCancel()
+			allocCancel()
+		},
+	}
+}
+
+// Close releases the underlying Chromium process.
+func (b *Browser) Close() {
+	b.cancel()
+}
+
+// Fetch opens a new tab off the shared browser context, navigates to
+// url, waits for waitSelector to appear, and returns the rendered page
+// HTML. Each call gets its own tab so concurrent sources don't race on
+// navigation state, while still sharing the one browser process.
+func (b *Browser) Fetch(url, waitSelector string, timeout time.Duration) (string, error) {
+	tabCtx, cancelTab := chromedp.NewContext(b.ctx)
+	defer cancelTab()
+
+	tabCtx, cancelTimeout := context.WithTimeout(tabCtx, timeout)
+	defer cancelTimeout()
+
+	var html string
+	err := chromedp.Run(tabCtx,
+		chromedp.Navigate(url),
+		chromedp.WaitVisible(waitSelector, chromedp.ByQuery),
+		chromedp.OuterHTML("html", &html),
+	)
+	if err != nil {
+		return "", fmt.Errorf("render: fetch %s: %w", url, err)
+	}
+	return html, nil
+}
@@ -0,0 +1,114 @@
+// Package bgphe implements a finder.Source backed by bgp.he.net, which
+// publishes the IPv4 and IPv6 prefixes announced by an ASN.
+package bgphe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tr3ss/gohfinder/pkg/finder"
+	"github.com/tr3ss/gohfinder/pkg/render"
+)
+
+// userAgent is set on every request to avoid being blocked by bgp.he.net.
+const userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36"
+
+const baseURL = "https://bgp.he.net/"
+
+// renderWaitSelector is the table whose absence signals an anti-bot
+// interstitial or an unrendered, JS-populated page.
+const renderWaitSelector = "#table_prefixes4"
+
+// renderTimeout bounds how long a single headless-browser fallback may run.
+const renderTimeout = 20 * time.Second
+
+// Source queries bgp.he.net for ASN prefixes. It does not resolve
+// hostnames within a CIDR, so LookupCIDR always returns (nil, nil).
+type Source struct {
+	Client *http.Client
+
+	// Render, if set, is used to re-fetch the ASN page via headless
+	// Chromium whenever the plain HTTP response parses to zero prefixes.
+	Render *render.Browser
+}
+
+// New returns a bgp.he.net-backed source using client. A nil client
+// falls back to http.DefaultClient.
+func New(client *http.Client) *Source {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Source{Client: client}
+}
+
+func (s *Source) Name() string { return "bgp" }
+
+func (s *Source) LookupASN(ctx context.Context, asn string) ([]finder.Prefix, error) {
+	doc, err := s.fetch(ctx, asn)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := parsePrefixes(doc)
+	if len(prefixes) == 0 && s.Render != nil {
+		html, err := s.Render.Fetch(baseURL+asn, renderWaitSelector, renderTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("bgphe: render fallback for %s: %w", asn, err)
+		}
+		rdoc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		if err != nil {
+			return nil, fmt.Errorf("bgphe: parse rendered page for %s: %w", asn, err)
+		}
+		prefixes = parsePrefixes(rdoc)
+	}
+
+	return prefixes, nil
+}
+
+func (s *Source) fetch(ctx context.Context, asn string) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+asn, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bgphe: build request for %s: %w", asn, err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("bgphe: fetch ASN page for %s: %w", asn, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bgphe: ASN page for %s returned status %d", asn, resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("bgphe: parse ASN page for %s: %w", asn, err)
+	}
+	return doc, nil
+}
+
+func parsePrefixes(doc *goquery.Document) []finder.Prefix {
+	var prefixes []finder.Prefix
+	for table, family := range map[string]string{
+		"#table_prefixes4": "ipv4",
+		"#table_prefixes6": "ipv6",
+	} {
+		doc.Find(table + " tbody tr").Each(func(i int, sel *goquery.Selection) {
+			cidr := strings.TrimSpace(sel.Find("td").First().Find("a").Text())
+			if cidr != "" {
+				prefixes = append(prefixes, finder.Prefix{CIDR: cidr, Family: family})
+			}
+		})
+	}
+	return prefixes
+}
+
+func (s *Source) LookupCIDR(ctx context.Context, cidr string) ([]finder.Host, error) {
+	return nil, nil
+}
@@ -0,0 +1,48 @@
+package bgphe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const samplePage = `
+<html><body>
+<table id="table_prefixes4"><tbody>
+<tr><td><a>203.0.113.0/24</a></td></tr>
+<tr><td><a>198.51.100.0/24</a></td></tr>
+</tbody></table>
+<table id="table_prefixes6"><tbody>
+<tr><td><a>2001:db8::/32</a></td></tr>
+</tbody></table>
+</body></html>
+`
+
+func TestParsePrefixesSplitsByFamily(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(samplePage))
+	if err != nil {
+		t.Fatalf("parse sample page: %v", err)
+	}
+
+	prefixes := parsePrefixes(doc)
+
+	var v4, v6 []string
+	for _, p := range prefixes {
+		switch p.Family {
+		case "ipv4":
+			v4 = append(v4, p.CIDR)
+		case "ipv6":
+			v6 = append(v6, p.CIDR)
+		default:
+			t.Fatalf("prefix %q has unexpected family %q", p.CIDR, p.Family)
+		}
+	}
+
+	if len(v4) != 2 || v4[0] != "203.0.113.0/24" || v4[1] != "198.51.100.0/24" {
+		t.Fatalf("got ipv4 prefixes %v, want [203.0.113.0/24 198.51.100.0/24]", v4)
+	}
+	if len(v6) != 1 || v6[0] != "2001:db8::/32" {
+		t.Fatalf("got ipv6 prefixes %v, want [2001:db8::/32]", v6)
+	}
+}
@@ -0,0 +1,158 @@
+// Package ct implements a finder.Source backed by crt.sh's Certificate
+// Transparency log search. It surfaces hostnames that passive DNS views
+// like Robtex miss — recently issued certificates and wildcard-covered
+// names — by searching crt.sh for a single IP (a /32 or /128 CIDR) and
+// for the organization name an ASN is registered under.
+package ct
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tr3ss/gohfinder/pkg/finder"
+)
+
+// userAgent is set on every request to avoid being blocked.
+const userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36"
+
+// crtShURL and bgpHEURL are vars rather than consts so tests can point
+// them at an httptest server.
+var (
+	crtShURL = "https://crt.sh/"
+	bgpHEURL = "https://bgp.he.net/"
+)
+
+// Source queries crt.sh. It has no notion of ASN-to-prefix mappings, so
+// LookupASN always returns (nil, nil); ASN coverage instead comes through
+// LookupOrg, which Finder.FindASN calls via the OrgLookupSource interface.
+type Source struct {
+	Client *http.Client
+}
+
+// New returns a crt.sh-backed source using client. A nil client falls
+// back to http.DefaultClient.
+func New(client *http.Client) *Source {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Source{Client: client}
+}
+
+func (s *Source) Name() string { return "ct" }
+
+func (s *Source) LookupASN(ctx context.Context, asn string) ([]finder.Prefix, error) {
+	return nil, nil
+}
+
+// LookupCIDR searches crt.sh for certificates whose subject or SAN
+// matches the given IP. crt.sh's q= parameter does exact/substring text
+// matching against certificate Subject/SAN fields, not CIDR-range
+// matching, so a multi-address block has no single query that could
+// return it: for anything wider than a single address (i.e. not a /32
+// or /128), LookupCIDR returns (nil, nil) rather than silently querying
+// for text that can't match.
+func (s *Source) LookupCIDR(ctx context.Context, cidr string) ([]finder.Host, error) {
+	ip, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("ct: invalid CIDR %q: %w", cidr, err)
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if ones != bits {
+		return nil, nil
+	}
+	return s.query(ctx, "q", ip.String())
+}
+
+// LookupOrg searches crt.sh for certificates issued to the organization
+// an ASN is registered under, as scraped from its bgp.he.net page.
+func (s *Source) LookupOrg(ctx context.Context, asn string) ([]finder.Host, error) {
+	org, err := s.resolveOrgName(ctx, asn)
+	if err != nil || org == "" {
+		return nil, err
+	}
+	return s.query(ctx, "O", org)
+}
+
+// resolveOrgName scrapes the registered organization name off an ASN's
+// bgp.he.net page, e.g. "AS15169 Google LLC" -> "Google LLC".
+func (s *Source) resolveOrgName(ctx context.Context, asn string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", bgpHEURL+asn, nil)
+	if err != nil {
+		return "", fmt.Errorf("ct: build request for %s: %w", asn, err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("ct: fetch ASN page for %s: %w", asn, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("ct: ASN page for %s returned status %d", asn, resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("ct: parse ASN page for %s: %w", asn, err)
+	}
+
+	heading := strings.TrimSpace(doc.Find("#content h1").First().Text())
+	return strings.TrimSpace(strings.TrimPrefix(heading, asn)), nil
+}
+
+type certEntry struct {
+	NameValue string `json:"name_value"`
+}
+
+func (s *Source) query(ctx context.Context, param, value string) ([]finder.Host, error) {
+	q := url.Values{}
+	q.Set(param, value)
+	q.Set("output", "json")
+	reqURL := crtShURL + "?" + q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ct: build request for %s: %w", value, err)
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ct: fetch crt.sh results for %s: %w", value, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ct: crt.sh query for %s returned status %d", value, resp.StatusCode)
+	}
+
+	var entries []certEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("ct: decode crt.sh response for %s: %w", value, err)
+	}
+
+	seen := make(map[string]struct{})
+	var hosts []finder.Host
+	for _, e := range entries {
+		for _, name := range strings.Split(e.NameValue, "\n") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if _, exists := seen[name]; exists {
+				continue
+			}
+			seen[name] = struct{}{}
+			hosts = append(hosts, finder.Host{Hostname: name})
+		}
+	}
+
+	return hosts, nil
+}
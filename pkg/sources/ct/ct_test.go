@@ -0,0 +1,70 @@
+package ct
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestQueryEscapesValue(t *testing.T) {
+	var gotRawQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRawQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	orig := crtShURL
+	crtShURL = srv.URL + "/"
+	defer func() { crtShURL = orig }()
+
+	s := New(srv.Client())
+	if _, err := s.query(context.Background(), "O", "Google LLC"); err != nil {
+		t.Fatalf("query returned error: %v", err)
+	}
+
+	q, err := url.ParseQuery(gotRawQuery)
+	if err != nil {
+		t.Fatalf("server received unparseable query %q: %v", gotRawQuery, err)
+	}
+	if got := q.Get("O"); got != "Google LLC" {
+		t.Fatalf("O param round-tripped as %q, want %q", got, "Google LLC")
+	}
+}
+
+func TestLookupCIDRQueriesOnlySingleAddresses(t *testing.T) {
+	var queries int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	orig := crtShURL
+	crtShURL = srv.URL + "/"
+	defer func() { crtShURL = orig }()
+
+	s := New(srv.Client())
+
+	if _, err := s.LookupCIDR(context.Background(), "203.0.113.0/24"); err != nil {
+		t.Fatalf("LookupCIDR(/24) returned error: %v", err)
+	}
+	if queries != 0 {
+		t.Fatalf("LookupCIDR queried crt.sh %d times for a /24, want 0 (no CIDR-range syntax exists)", queries)
+	}
+
+	if _, err := s.LookupCIDR(context.Background(), "203.0.113.5/32"); err != nil {
+		t.Fatalf("LookupCIDR(/32) returned error: %v", err)
+	}
+	if queries != 1 {
+		t.Fatalf("LookupCIDR queried crt.sh %d times for a /32, want 1", queries)
+	}
+
+	if _, err := s.LookupCIDR(context.Background(), "not-a-cidr"); err == nil {
+		t.Fatal("LookupCIDR accepted an invalid CIDR without error")
+	}
+}
@@ -0,0 +1,135 @@
+// Package robtex implements a finder.Source backed by robtex.com's passive
+// DNS view of a CIDR block.
+package robtex
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tr3ss/gohfinder/pkg/finder"
+	"github.com/tr3ss/gohfinder/pkg/render"
+)
+
+// userAgent is set on every request to avoid being blocked by robtex.com.
+const userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36"
+
+const baseURL = "https://www.robtex.com/cidr/"
+
+// renderWaitSelector is the anchor pattern whose absence signals an
+// anti-bot interstitial or an unrendered, lazy-loaded table.
+const renderWaitSelector = "a[href^='https://www.robtex.com/dns-lookup/']"
+
+// renderTimeout bounds how long a single headless-browser fallback may run.
+const renderTimeout = 20 * time.Second
+
+// Source queries robtex.com for the hosts it has seen within a CIDR. It
+// has no notion of ASN prefixes, so LookupASN always returns (nil, nil).
+type Source struct {
+	Client *http.Client
+
+	// Render, if set, is used to re-fetch the CIDR page via headless
+	// Chromium whenever the plain HTTP response parses to zero hosts.
+	Render *render.Browser
+}
+
+// New returns a robtex.com-backed source using client. A nil client
+// falls back to http.DefaultClient.
+func New(client *http.Client) *Source {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Source{Client: client}
+}
+
+func (s *Source) Name() string { return "robtex" }
+
+func (s *Source) LookupASN(ctx context.Context, asn string) ([]finder.Prefix, error) {
+	return nil, nil
+}
+
+func (s *Source) LookupCIDR(ctx context.Context, cidr string) ([]finder.Host, error) {
+	uri, family, err := cidrPath(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("robtex: %w", err)
+	}
+
+	doc, err := s.fetch(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := parseHosts(doc, family)
+	if len(hosts) == 0 && s.Render != nil {
+		html, err := s.Render.Fetch(baseURL+uri, renderWaitSelector, renderTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("robtex: render fallback for %s: %w", cidr, err)
+		}
+		rdoc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		if err != nil {
+			return nil, fmt.Errorf("robtex: parse rendered page for %s: %w", cidr, err)
+		}
+		hosts = parseHosts(rdoc, family)
+	}
+
+	return hosts, nil
+}
+
+func (s *Source) fetch(ctx context.Context, uri string) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("robtex: build request for %s: %w", uri, err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("robtex: fetch CIDR page for %s: %w", uri, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("robtex: CIDR page for %s returned status %d", uri, resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("robtex: parse CIDR page for %s: %w", uri, err)
+	}
+	return doc, nil
+}
+
+func parseHosts(doc *goquery.Document, family string) []finder.Host {
+	var hosts []finder.Host
+	doc.Find("a[href^='https://www.robtex.com/dns-lookup/']").Each(func(i int, sel *goquery.Selection) {
+		hostname := strings.Replace(sel.AttrOr("href", ""), "https://www.robtex.com/dns-lookup/", "", 1)
+		ip := sel.Parent().Parent().Find("a[href^='https://www.robtex.com/ip-lookup/']").AttrOr("href", "")
+		ip = strings.Replace(ip, "https://www.robtex.com/ip-lookup/", "", 1)
+
+		hosts = append(hosts, finder.Host{Hostname: hostname, IP: ip, Family: family})
+	})
+	return hosts
+}
+
+// cidrPath normalizes a CIDR (IPv4 or IPv6) into the path segment Robtex
+// expects after "/cidr/". IPv4 ranges use a dash between the network
+// address and prefix length (e.g. "1.2.3.0-24"); IPv6 addresses already
+// contain colons, so the network/prefix separator is an underscore
+// instead (e.g. "2001:db8::_32") to keep the path unambiguous.
+func cidrPath(cidr string) (path string, family string, err error) {
+	ip, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+
+	ones, _ := ipNet.Mask.Size()
+	network := ipNet.IP.String()
+	if ip.To4() != nil {
+		return fmt.Sprintf("%s-%d", network, ones), "ipv4", nil
+	}
+	return fmt.Sprintf("%s_%d", network, ones), "ipv6", nil
+}
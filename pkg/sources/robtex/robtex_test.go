@@ -0,0 +1,27 @@
+package robtex
+
+import "testing"
+
+func TestCidrPathUsesNetworkAddress(t *testing.T) {
+	cases := []struct {
+		cidr   string
+		path   string
+		family string
+	}{
+		{"1.2.3.4/24", "1.2.3.0-24", "ipv4"},
+		{"203.0.113.5/24", "203.0.113.0-24", "ipv4"},
+		{"203.0.113.0/24", "203.0.113.0-24", "ipv4"},
+		{"2001:db8::1/32", "2001:db8::_32", "ipv6"},
+	}
+
+	for _, tc := range cases {
+		path, family, err := cidrPath(tc.cidr)
+		if err != nil {
+			t.Errorf("cidrPath(%q) returned error: %v", tc.cidr, err)
+			continue
+		}
+		if path != tc.path || family != tc.family {
+			t.Errorf("cidrPath(%q) = (%q, %q), want (%q, %q)", tc.cidr, path, family, tc.path, tc.family)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package verify
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/tr3ss/gohfinder/pkg/finder"
+)
+
+// Pipeline wraps next so that every Observation handed to the returned
+// emit function is first actively re-resolved against resolver and
+// annotated with its live A/AAAA/CNAME chain before reaching next.
+// Observations whose live answers fall outside their queried CIDR are
+// considered stale; stale observations are dropped unless includeStale
+// is set, in which case they're passed through tagged Stale. Lookups
+// run across a bounded worker pool, so callers must invoke the returned
+// wait func once they're done emitting to block until every in-flight
+// verification has either reached next or been dropped.
+func Pipeline(ctx context.Context, resolver *Resolver, concurrency int, includeStale bool, next func(finder.Observation)) (emit func(finder.Observation), wait func()) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	emit = func(o finder.Observation) {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			verified, ok := annotate(ctx, resolver, o)
+			if !ok && !includeStale {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			next(verified)
+		}()
+	}
+
+	wait = wg.Wait
+	return emit, wait
+}
+
+// annotate resolves o.Hostname and returns the annotated Observation
+// plus whether it should be kept when includeStale is false (i.e.
+// whether it verified as live).
+func annotate(ctx context.Context, resolver *Resolver, o finder.Observation) (finder.Observation, bool) {
+	chain, err := resolver.Resolve(ctx, o.Hostname)
+	if err != nil {
+		o.Stale = true
+		return o, false
+	}
+
+	o.LiveA = chain.A
+	o.LiveAAAA = chain.AAAA
+	o.CNAME = chain.CNAME
+
+	_, cidr, err := net.ParseCIDR(o.CIDR)
+	switch {
+	case err != nil:
+		// No CIDR to check against (e.g. a CT hit with no address
+		// context); treat any live answer as verified.
+		o.Verified = !chain.Empty()
+	default:
+		o.Verified = chain.ContainsIP(cidr)
+	}
+
+	o.Stale = !o.Verified
+	return o, o.Verified
+}
@@ -0,0 +1,151 @@
+// Package verify actively resolves hostnames discovered by passive
+// sources against a specific nameserver, to catch the stale records that
+// Robtex's passive DNS view frequently returns for addresses that have
+// since been reassigned.
+package verify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// Chain is the live address/alias chain observed for a hostname at
+// verification time.
+type Chain struct {
+	A     []string
+	AAAA  []string
+	CNAME []string
+}
+
+// ContainsIP reports whether the chain includes an address within cidr.
+func (c Chain) ContainsIP(cidr *net.IPNet) bool {
+	for _, ip := range c.A {
+		if addr := net.ParseIP(ip); addr != nil && cidr.Contains(addr) {
+			return true
+		}
+	}
+	for _, ip := range c.AAAA {
+		if addr := net.ParseIP(ip); addr != nil && cidr.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Empty reports whether the chain resolved to nothing at all.
+func (c Chain) Empty() bool {
+	return len(c.A) == 0 && len(c.AAAA) == 0 && len(c.CNAME) == 0
+}
+
+// Resolver performs direct A/AAAA/CNAME lookups against a specific
+// nameserver, rather than the system resolver, so results reflect the
+// live record a single authority is currently serving. The transport is
+// selected by the scheme NewResolver was given: plain DNS over UDP/TCP
+// by default, DoT for a "tls://" server, or DoH for an "https://" one.
+type Resolver struct {
+	Server string
+	Client *dns.Client
+
+	// doh is set instead of Client when Server used an "https://" scheme.
+	doh *dohTransport
+}
+
+// NewResolver returns a Resolver for server, which may be:
+//   - "host:port" or "udp://host:port" for plain DNS (the default),
+//   - "tls://host:port" for DNS-over-TLS, or
+//   - "https://host/path" for DNS-over-HTTPS (RFC 8484).
+func NewResolver(server string) *Resolver {
+	switch {
+	case strings.HasPrefix(server, "https://"):
+		return &Resolver{Server: server, doh: &dohTransport{endpoint: server, client: http.DefaultClient}}
+	case strings.HasPrefix(server, "tls://"):
+		return &Resolver{Server: strings.TrimPrefix(server, "tls://"), Client: &dns.Client{Net: "tcp-tls"}}
+	default:
+		return &Resolver{Server: strings.TrimPrefix(server, "udp://"), Client: new(dns.Client)}
+	}
+}
+
+// Resolve looks up the A, AAAA and CNAME records for hostname.
+func (r *Resolver) Resolve(ctx context.Context, hostname string) (Chain, error) {
+	var chain Chain
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA, dns.TypeCNAME} {
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(hostname), qtype)
+
+		in, err := r.exchange(ctx, msg)
+		if err != nil {
+			return chain, fmt.Errorf("verify: resolve %s: %w", hostname, err)
+		}
+
+		for _, ans := range in.Answer {
+			switch rec := ans.(type) {
+			case *dns.A:
+				chain.A = append(chain.A, rec.A.String())
+			case *dns.AAAA:
+				chain.AAAA = append(chain.AAAA, rec.AAAA.String())
+			case *dns.CNAME:
+				chain.CNAME = append(chain.CNAME, rec.Target)
+			}
+		}
+	}
+	return chain, nil
+}
+
+// exchange sends msg over whichever transport this Resolver was
+// configured with.
+func (r *Resolver) exchange(ctx context.Context, msg *dns.Msg) (*dns.Msg, error) {
+	if r.doh != nil {
+		return r.doh.exchange(ctx, msg)
+	}
+	in, _, err := r.Client.ExchangeContext(ctx, msg, r.Server)
+	return in, err
+}
+
+// dohTransport exchanges DNS messages over HTTPS using the wire-format
+// encoding described in RFC 8484.
+type dohTransport struct {
+	endpoint string
+	client   *http.Client
+}
+
+func (d *dohTransport) exchange(ctx context.Context, msg *dns.Msg) (*dns.Msg, error) {
+	packed, err := msg.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("pack query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", d.endpoint, bytes.NewReader(packed))
+	if err != nil {
+		return nil, fmt.Errorf("build DoH request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("DoH request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DoH endpoint returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read DoH response: %w", err)
+	}
+
+	in := new(dns.Msg)
+	if err := in.Unpack(body); err != nil {
+		return nil, fmt.Errorf("unpack DoH response: %w", err)
+	}
+	return in, nil
+}
@@ -0,0 +1,113 @@
+package verify
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/tr3ss/gohfinder/pkg/finder"
+)
+
+// dohStub returns a DoH server that always answers A queries with addr.
+func dohStub(t *testing.T, addr string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := make([]byte, r.ContentLength)
+		if _, err := r.Body.Read(body); err != nil && len(body) == 0 {
+			t.Fatalf("read DoH request body: %v", err)
+		}
+		q := new(dns.Msg)
+		if err := q.Unpack(body); err != nil {
+			t.Fatalf("unpack DoH request: %v", err)
+		}
+
+		resp := new(dns.Msg)
+		resp.SetReply(q)
+		if q.Question[0].Qtype == dns.TypeA {
+			rr, err := dns.NewRR(q.Question[0].Name + " 300 IN A " + addr)
+			if err != nil {
+				t.Fatalf("build answer RR: %v", err)
+			}
+			resp.Answer = append(resp.Answer, rr)
+		}
+
+		packed, err := resp.Pack()
+		if err != nil {
+			t.Fatalf("pack DoH response: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/dns-message")
+		w.Write(packed)
+	}))
+}
+
+func TestNewResolverSelectsTransport(t *testing.T) {
+	if r := NewResolver("1.1.1.1:53"); r.Client == nil || r.Client.Net != "" || r.doh != nil {
+		t.Fatalf("plain server: got Client=%+v doh=%v, want plain UDP/TCP client", r.Client, r.doh)
+	}
+	if r := NewResolver("tls://1.1.1.1:853"); r.Client == nil || r.Client.Net != "tcp-tls" || r.Server != "1.1.1.1:853" {
+		t.Fatalf("tls:// server: got Client=%+v Server=%q, want tcp-tls client against 1.1.1.1:853", r.Client, r.Server)
+	}
+	if r := NewResolver("https://dns.example/dns-query"); r.doh == nil || r.Client != nil {
+		t.Fatalf("https:// server: got Client=%v doh=%v, want a doh transport and no dns.Client", r.Client, r.doh)
+	}
+}
+
+func TestResolverDoHRoundTrip(t *testing.T) {
+	srv := dohStub(t, "203.0.113.9")
+	defer srv.Close()
+
+	// httptest.NewServer speaks plain HTTP, so build the Resolver
+	// directly rather than through NewResolver's "https://" sniffing.
+	r := &Resolver{Server: srv.URL, doh: &dohTransport{endpoint: srv.URL, client: srv.Client()}}
+	chain, err := r.Resolve(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if len(chain.A) != 1 || chain.A[0] != "203.0.113.9" {
+		t.Fatalf("got A=%v, want [203.0.113.9]", chain.A)
+	}
+}
+
+func TestChainContainsIPAndEmpty(t *testing.T) {
+	_, cidr, err := net.ParseCIDR("203.0.113.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	live := Chain{A: []string{"203.0.113.9"}}
+	if !live.ContainsIP(cidr) {
+		t.Fatal("expected live chain to be contained in the queried CIDR")
+	}
+
+	drifted := Chain{A: []string{"198.51.100.9"}}
+	if drifted.ContainsIP(cidr) {
+		t.Fatal("expected drifted chain to fall outside the queried CIDR")
+	}
+
+	var empty Chain
+	if !empty.Empty() {
+		t.Fatal("expected zero-value Chain to be Empty")
+	}
+}
+
+func TestAnnotateDropsRecordsOutsideQueriedCIDR(t *testing.T) {
+	srv := dohStub(t, "198.51.100.9")
+	defer srv.Close()
+
+	r := &Resolver{Server: srv.URL, doh: &dohTransport{endpoint: srv.URL, client: srv.Client()}}
+	o := finder.Observation{Hostname: "example.com", CIDR: "203.0.113.0/24"}
+
+	annotated, verified := annotate(context.Background(), r, o)
+	if verified {
+		t.Fatal("expected an address outside the queried CIDR to verify as false")
+	}
+	if !annotated.Stale {
+		t.Fatal("expected annotated Observation to be tagged Stale")
+	}
+	if len(annotated.LiveA) != 1 || annotated.LiveA[0] != "198.51.100.9" {
+		t.Fatalf("got LiveA=%v, want [198.51.100.9]", annotated.LiveA)
+	}
+}